@@ -0,0 +1,155 @@
+package skiplist
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// nextSlotSize and spanSlotSize are the per-slot footprints of the
+// next/span pools below, used to report bytesAllocated in the same units
+// as the Element chunk. Neither depends on K: atomic.Pointer[T] is always
+// pointer-sized regardless of T, and span slots are always uint32.
+var (
+	nextSlotSize = int(unsafe.Sizeof(atomic.Pointer[Element[float64]]{}))
+	spanSlotSize = int(unsafe.Sizeof(uint32(0)))
+)
+
+// initialSliceSlots is the starting capacity, in slots, of the arena's
+// next/span pools -- a modest multiple of DefaultMaxLevel so the first
+// few inserts don't immediately force a grow.
+const initialSliceSlots = 64
+
+// slicePool is a bump allocator for same-typed slices of varying length --
+// the per-level next/span slices a new node needs, sized by the node's
+// random height. Like arena's Element chunk, it grows (doubling) when a
+// request doesn't fit in what's left of the current chunk, and abandons
+// rather than extends the old chunk; pointers already handed out of it
+// stay valid because the Elements holding them keep the old backing
+// array alive.
+type slicePool[T any] struct {
+	chunkSize int
+	chunk     []T
+	used      int
+	allocated int
+}
+
+func newSlicePool[T any](initialSlots int) *slicePool[T] {
+	p := &slicePool[T]{chunkSize: initialSlots}
+	p.grow(initialSlots)
+	return p
+}
+
+// grow replaces the pool's chunk with a new one able to hold at least
+// slots elements.
+func (p *slicePool[T]) grow(slots int) {
+	if slots < 1 {
+		slots = 1
+	}
+
+	p.chunk = make([]T, slots)
+	p.used = 0
+	p.allocated += slots
+}
+
+// alloc carves a slice of length n out of the pool, growing (and doubling
+// the chunk size, at least enough to fit n) if the current chunk has no
+// room left.
+func (p *slicePool[T]) alloc(n int) []T {
+	if p.used+n > len(p.chunk) {
+		for p.chunkSize < n {
+			p.chunkSize *= 2
+		}
+		p.chunkSize *= 2
+		p.grow(p.chunkSize)
+	}
+
+	s := p.chunk[p.used : p.used+n : p.used+n]
+	p.used += n
+	return s
+}
+
+// arena is a simple bump-pointer pool: it carves Elements, and their
+// next/span slices, out of pre-grown chunks instead of allocating each of
+// the three on the heap individually, which is the dominant allocation
+// cost on insert-heavy workloads -- once its chunks have room, the only
+// heap allocation a Set against an arena-backed list makes is the boxed
+// value (see BenchmarkIncSetArena). It is not a true offset-arithmetic arena: there's
+// no free list or manual byte layout, just slab allocation of whole
+// Element structs and whole next/span slices. When a chunk fills up,
+// alloc replaces it with a bigger one rather than extending it in place;
+// the old chunk is not tracked, but pointers already handed out of it
+// stay valid because those Elements (reachable from the skip list) keep
+// the old backing array alive.
+type arena[K any] struct {
+	chunkSize int
+	chunk     []Element[K]
+	used      int
+	allocated int
+
+	nextPool *slicePool[atomic.Pointer[Element[K]]]
+	spanPool *slicePool[uint32]
+}
+
+// newArena creates an arena pre-grown to hold initialBytes/sizeof(Element)
+// Elements, and at least one.
+func newArena[K any](initialBytes int) *arena[K] {
+	elementSize := int(unsafe.Sizeof(Element[K]{}))
+	if initialBytes < elementSize {
+		initialBytes = elementSize
+	}
+
+	a := &arena[K]{
+		chunkSize: initialBytes,
+		nextPool:  newSlicePool[atomic.Pointer[Element[K]]](initialSliceSlots),
+		spanPool:  newSlicePool[uint32](initialSliceSlots),
+	}
+	a.grow(initialBytes)
+	return a
+}
+
+// grow replaces the arena's chunk with a new one holding
+// bytes/sizeof(Element) Elements, and at least one.
+func (a *arena[K]) grow(bytes int) {
+	elementSize := int(unsafe.Sizeof(Element[K]{}))
+	n := bytes / elementSize
+	if n < 1 {
+		n = 1
+	}
+
+	a.chunk = make([]Element[K], n)
+	a.used = 0
+	a.allocated += n * elementSize
+}
+
+// alloc returns a pointer to a zeroed Element carved out of the arena,
+// growing the arena (doubling the chunk size) if the current chunk is full.
+func (a *arena[K]) alloc() *Element[K] {
+	if a.used >= len(a.chunk) {
+		a.chunkSize *= 2
+		a.grow(a.chunkSize)
+	}
+
+	e := &a.chunk[a.used]
+	a.used++
+	return e
+}
+
+// allocNext carves a next slice of the given height out of the arena's
+// next pool, instead of Set heap-allocating one per insert.
+func (a *arena[K]) allocNext(height int) []atomic.Pointer[Element[K]] {
+	return a.nextPool.alloc(height)
+}
+
+// allocSpan carves a span slice of the given height out of the arena's
+// span pool, instead of Set heap-allocating one per insert.
+func (a *arena[K]) allocSpan(height int) []uint32 {
+	return a.spanPool.alloc(height)
+}
+
+// bytesAllocated reports the total number of bytes reserved across the
+// arena's Element chunk and its next/span pools.
+func (a *arena[K]) bytesAllocated() int {
+	return a.allocated +
+		a.nextPool.allocated*nextSlotSize +
+		a.spanPool.allocated*spanSlotSize
+}
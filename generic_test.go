@@ -0,0 +1,109 @@
+package skiplist
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type stringComparer struct{}
+
+func (stringComparer) Compare(a, b string) int {
+	return strings.Compare(a, b)
+}
+
+type byteSliceComparer struct{}
+
+func (byteSliceComparer) Compare(a, b []byte) int {
+	return bytes.Compare(a, b)
+}
+
+type person struct {
+	last  string
+	first string
+}
+
+type personComparer struct{}
+
+func (personComparer) Compare(a, b person) int {
+	if c := strings.Compare(a.last, b.last); c != 0 {
+		return c
+	}
+	return strings.Compare(a.first, b.first)
+}
+
+func TestGenericStringKeys(t *testing.T) {
+	list := NewGeneric[string](stringComparer{})
+
+	list.Set("banana", 2)
+	list.Set("apple", 1)
+	list.Set("cherry", 3)
+
+	if list.Length != 3 {
+		t.Fatal("wrong list length", list.Length)
+	}
+
+	var got []string
+	for c := list.Front(); c != nil; c = c.Next() {
+		got = append(got, c.Key())
+	}
+
+	want := []string{"apple", "banana", "cherry"}
+	for i, k := range want {
+		if got[i] != k {
+			t.Fatalf("wrong order: got %v, want %v", got, want)
+		}
+	}
+
+	if v := list.Get("banana"); v == nil || v.Value().(int) != 2 {
+		t.Fatal(`wrong "banana" value`)
+	}
+
+	if r := list.Remove("apple"); r == nil {
+		t.Fatal(`failed to remove "apple"`)
+	}
+
+	if list.Get("apple") != nil {
+		t.Fatal(`"apple" should have been removed`)
+	}
+}
+
+func TestGenericByteSliceKeys(t *testing.T) {
+	list := NewGeneric[[]byte](byteSliceComparer{})
+
+	list.Set([]byte("bb"), 2)
+	list.Set([]byte("aa"), 1)
+	list.Set([]byte("ab"), 3)
+
+	var got []string
+	for c := list.Front(); c != nil; c = c.Next() {
+		got = append(got, string(c.Key()))
+	}
+
+	want := []string{"aa", "ab", "bb"}
+	for i, k := range want {
+		if got[i] != k {
+			t.Fatalf("wrong lexicographic order: got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestGenericCompositeKeys(t *testing.T) {
+	list := NewGeneric[person](personComparer{})
+
+	list.Set(person{"Smith", "Bob"}, 1)
+	list.Set(person{"Adams", "Zoe"}, 2)
+	list.Set(person{"Smith", "Alice"}, 3)
+
+	var got []person
+	for c := list.Front(); c != nil; c = c.Next() {
+		got = append(got, c.Key())
+	}
+
+	want := []person{{"Adams", "Zoe"}, {"Smith", "Alice"}, {"Smith", "Bob"}}
+	for i, k := range want {
+		if got[i] != k {
+			t.Fatalf("wrong order: got %v, want %v", got, want)
+		}
+	}
+}
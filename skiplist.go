@@ -3,6 +3,7 @@ package skiplist
 import (
 	"math"
 	"math/rand"
+	"sync/atomic"
 	"time"
 )
 
@@ -12,89 +13,229 @@ var (
 )
 
 // Front returns the head node of the list.
-func (list *SkipList) Front() *Element {
-	return list.next[0]
+func (list *List[K]) Front() *Element[K] {
+	return list.next[0].Load()
 }
 
-// Next returns the following Element or nil if we're at the end of the list.
-// Only operates on the bottom level of the skip list (a fully linked list).
-func (element *Element) Next() *Element {
-	return element.next[0]
+// Back returns the tail node of the list, or nil if the list is empty.
+func (list *List[K]) Back() *Element[K] {
+	return list.tail.Load()
 }
 
-// Set inserts a value in the list with the specified key, ordered by the key.
-// If the key exists, it updates the value in the existing node.
+// Set inserts a value in the list with the specified key, ordered by the
+// Comparer. If the key exists, it updates the value in the existing node.
 // Returns a pointer to the new element.
-// Locking is optimistic and happens only after searching.
-func (list *SkipList) Set(key float64, value interface{}) *Element {
-	var element *Element
-
-	prevs := list.getPrevElementNodes(key)
+// Set serializes with other writers on list.mutex; it does not block Get or Seek.
+func (list *List[K]) Set(key K, value interface{}) *Element[K] {
+	var element *Element[K]
 
 	list.mutex.Lock()
 	defer list.mutex.Unlock()
 
-	if element = prevs[0].next[0]; element != nil && element.key <= key {
-		element.value = value
+	prevs := list.getPrevElementNodes(key)
+
+	if element = prevs[0].next[0].Load(); element != nil && list.cmp.Compare(element.key, key) <= 0 {
+		element.setValue(value)
 		return element
 	}
 
-	element = &Element{
-		elementNode: elementNode{
-			next: make([]*Element, list.randLevel()),
-		},
-		key:   key,
-		value: value,
+	level := list.randLevel()
+	if list.arena != nil {
+		element = list.arena.alloc()
+		element.next = list.arena.allocNext(level)
+		element.span = list.arena.allocSpan(level)
+	} else {
+		element = &Element[K]{
+			elementNode: elementNode[K]{
+				next: make([]atomic.Pointer[Element[K]], level),
+			},
+		}
+		element.span = make([]uint32, level)
+	}
+	element.key = key
+	element.setValue(value)
+
+	ranks := list.rankCache
+	for i := 0; i < level; i++ {
+		element.next[i].Store(prevs[i].next[i].Load())
+		element.span[i] = prevs[i].span[i] - (ranks[0] - ranks[i])
+		prevs[i].next[i].Store(element)
+		prevs[i].span[i] = (ranks[0] - ranks[i]) + 1
 	}
 
-	for i := range element.next {
-		element.next[i] = prevs[i].next[i]
-		prevs[i].next[i] = element
+	for i := level; i < list.maxLevel; i++ {
+		prevs[i].span[i]++
 	}
 
-	list.length++
+	if prevs[0] != &list.elementNode {
+		element.prev.Store(elementFromNode(prevs[0]))
+	}
+	if next := element.next[0].Load(); next != nil {
+		next.prev.Store(element)
+	} else {
+		list.tail.Store(element)
+	}
+
+	list.Length++
 	return element
 }
 
-// Get finds an element by key. It returns element pointer if found, nil if not found.
-// Locking is optimistic and happens only after searching with a fast check for deletion after locking.
-func (list *SkipList) Get(key float64) *Element {
-	var prev *elementNode = &list.elementNode
-	var next *Element
+// descend is the shared forward scan behind Get, Seek, and
+// getPrevElementNodes: at each level it walks forward while the next
+// element's key is below key, then drops down a level, the same
+// predecessor-search every one of them needs. skipTombstones makes it
+// treat elements Remove has tombstoned but not yet unlinked as absent,
+// which Get/Seek want but writers (already holding list.mutex) don't.
+// onStep, if non-nil, is called before crossing each element during a
+// level's inner walk; onLevel, if non-nil, is called once per level with
+// the final predecessor reached on it. getPrevElementNodes uses both to
+// fold its rank bookkeeping into this same traversal instead of repeating it.
+func (list *List[K]) descend(key K, skipTombstones bool, onStep, onLevel func(level int, prev *elementNode[K])) *Element[K] {
+	var prev *elementNode[K] = &list.elementNode
+	var next *Element[K]
 
 	for i := list.maxLevel - 1; i >= 0; i-- {
-		next = prev.next[i]
+		next = prev.next[i].Load()
 
-		for next != nil && key > next.key {
+		for next != nil && (list.cmp.Compare(key, next.key) > 0 || (skipTombstones && next.deleted.Load())) {
+			if onStep != nil {
+				onStep(i, prev)
+			}
 			prev = &next.elementNode
-			next = next.next[i]
+			next = next.next[i].Load()
+		}
+
+		if onLevel != nil {
+			onLevel(i, prev)
 		}
 	}
 
-	list.mutex.Lock()
-	defer list.mutex.Unlock()
-	if next != nil && next.key <= key {
+	return next
+}
+
+// Get finds an element by key. It returns element pointer if found, nil if not found.
+// Get never blocks on list.mutex: it walks the next pointers atomically and
+// skips over elements that Remove has tombstoned but not yet fully unlinked.
+func (list *List[K]) Get(key K) *Element[K] {
+	if next := list.Seek(key); next != nil && list.cmp.Compare(next.key, key) <= 0 {
 		return next
 	}
-
 	return nil
 }
 
+// Seek returns a cursor at the first element with key >= target, or nil
+// if every element is less than target. The returned Element can be
+// walked forward with Next() or backward with Prev(). Like Get, Seek does
+// not take list.mutex.
+func (list *List[K]) Seek(key K) *Element[K] {
+	return list.descend(key, true, nil, nil)
+}
+
+// SeekLE returns a cursor at the last element with key <= target, or nil
+// if every element is greater than target. The returned Element can be
+// walked forward with Next() or backward with Prev().
+func (list *List[K]) SeekLE(key K) *Element[K] {
+	next := list.Seek(key)
+	if next != nil && list.cmp.Compare(next.key, key) == 0 {
+		return next
+	}
+
+	if next == nil {
+		return list.tail.Load()
+	}
+
+	return next.prev.Load()
+}
+
+// RangeOptions customizes the bounds and direction of a Range scan. The
+// zero value scans ascending with both bounds exclusive and no limit.
+type RangeOptions struct {
+	FromInclusive bool
+	ToInclusive   bool
+	Limit         int // 0 means no limit
+	Reverse       bool
+}
+
+// Range walks every element with from <= key <= to, in ascending order,
+// calling fn for each one. It stops early if fn returns false.
+func (list *List[K]) Range(from, to K, fn func(*Element[K]) bool) {
+	list.RangeWithOptions(from, to, RangeOptions{FromInclusive: true, ToInclusive: true}, fn)
+}
+
+// RangeWithOptions is like Range but lets the caller exclude either bound,
+// cap the number of elements visited, or scan in descending order. It is
+// built on Seek/SeekLE, so it costs no extra tree traversal versus Get.
+func (list *List[K]) RangeWithOptions(from, to K, opts RangeOptions, fn func(*Element[K]) bool) {
+	visited := 0
+	within := func() bool {
+		return opts.Limit <= 0 || visited < opts.Limit
+	}
+
+	if opts.Reverse {
+		cur := list.SeekLE(to)
+		if cur != nil && !opts.ToInclusive && list.cmp.Compare(cur.key, to) == 0 {
+			cur = cur.Prev()
+		}
+
+		for cur != nil && list.cmp.Compare(cur.key, from) >= 0 && within() {
+			if !opts.FromInclusive && list.cmp.Compare(cur.key, from) == 0 {
+				break
+			}
+			if !fn(cur) {
+				return
+			}
+			visited++
+			cur = cur.Prev()
+		}
+		return
+	}
+
+	cur := list.Seek(from)
+	if cur != nil && !opts.FromInclusive && list.cmp.Compare(cur.key, from) == 0 {
+		cur = cur.Next()
+	}
+
+	for cur != nil && list.cmp.Compare(cur.key, to) <= 0 && within() {
+		if !opts.ToInclusive && list.cmp.Compare(cur.key, to) == 0 {
+			break
+		}
+		if !fn(cur) {
+			return
+		}
+		visited++
+		cur = cur.Next()
+	}
+}
+
 // Remove deletes an element from the list.
 // Returns removed element pointer if found, nil if not found.
-// Locking is optimistic and happens only after searching with a fast check on adjacent nodes after locking.
-func (list *SkipList) Remove(key float64) *Element {
-	prevs := list.getPrevElementNodes(key)
-
-	// found the element, remove it
+// Remove tombstones the element before unlinking it, so a concurrent
+// lock-free Get/Seek that is already positioned on it sees the deletion
+// even if it observed some levels' next pointers before the unlink.
+func (list *List[K]) Remove(key K) *Element[K] {
 	list.mutex.Lock()
 	defer list.mutex.Unlock()
-	if element := prevs[0].next[0]; element != nil && element.key <= key {
-		for k, v := range element.next {
-			prevs[k].next[k] = v
+
+	prevs := list.getPrevElementNodes(key)
+
+	if element := prevs[0].next[0].Load(); element != nil && list.cmp.Compare(element.key, key) <= 0 {
+		element.deleted.Store(true)
+
+		for k := range element.next {
+			prevs[k].next[k].Store(element.next[k].Load())
+			prevs[k].span[k] += element.span[k] - 1
+		}
+		for k := len(element.next); k < list.maxLevel; k++ {
+			prevs[k].span[k]--
 		}
 
-		list.length--
+		if next := element.next[0].Load(); next != nil {
+			next.prev.Store(element.prev.Load())
+		} else {
+			list.tail.Store(element.prev.Load())
+		}
+
+		list.Length--
 		return element
 	}
 
@@ -105,31 +246,86 @@ func (list *SkipList) Remove(key float64) *Element {
 // Finds the previous nodes on each level relative to the current Element and
 // caches them. This approach is similar to a "search finger" as described by Pugh:
 // http://citeseerx.ist.psu.edu/viewdoc/summary?doi=10.1.1.17.524
-func (list *SkipList) getPrevElementNodes(key float64) []*elementNode {
-	var prev *elementNode = &list.elementNode
-	var next *Element
-
+//
+// It also fills list.rankCache with, for each level, the 1-indexed position of
+// prevs[i] in the list (0 for the list head) -- the rank bookkeeping Set and Remove need to
+// keep span up to date. Callers must hold list.mutex: Set and Remove are the
+// only callers, and both serialize on it for their entire duration, so the
+// shared prevNodesCache/rankCache buffers are never written concurrently.
+func (list *List[K]) getPrevElementNodes(key K) []*elementNode[K] {
 	prevs := list.prevNodesCache
+	ranks := list.rankCache
+
+	var rank uint32
+	list.descend(key, false,
+		func(level int, prev *elementNode[K]) { rank += prev.span[level] },
+		func(level int, prev *elementNode[K]) {
+			prevs[level] = prev
+			ranks[level] = rank
+		},
+	)
+
+	return prevs
+}
+
+// GetByRank returns the element at the given 1-indexed position in the
+// list's key order, or nil if n is out of range.
+func (list *List[K]) GetByRank(n int) *Element[K] {
+	if n < 1 {
+		return nil
+	}
 
+	rank := uint32(n)
+	var prev *elementNode[K] = &list.elementNode
+	var traversed uint32
+
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
 	for i := list.maxLevel - 1; i >= 0; i-- {
-		next = prev.next[i]
+		for next := prev.next[i].Load(); next != nil && traversed+prev.span[i] <= rank; next = prev.next[i].Load() {
+			traversed += prev.span[i]
+			prev = &next.elementNode
+		}
+
+		if traversed == rank {
+			return elementFromNode(prev)
+		}
+	}
 
-		for next != nil && key > next.key {
+	return nil
+}
+
+// Rank returns the 1-indexed position of key in the list's key order, or 0
+// if key is not present.
+func (list *List[K]) Rank(key K) int {
+	var prev *elementNode[K] = &list.elementNode
+	var rank uint32
+
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for next := prev.next[i].Load(); next != nil && list.cmp.Compare(next.key, key) < 0; next = prev.next[i].Load() {
+			rank += prev.span[i]
 			prev = &next.elementNode
-			next = next.next[i]
 		}
+	}
 
-		prevs[i] = prev
+	if next := prev.next[0].Load(); next != nil && list.cmp.Compare(next.key, key) == 0 {
+		rank += prev.span[0]
+		return int(rank)
 	}
 
-	return prevs
+	return 0
 }
 
 // SetMaxLevel changes the maximum level in the data structure.
 // It doesn't alter any existing data, only sets a limit on future insert heights.
 // newLevel must be between 1 and 64 inclusive.
 // Returns true if the level was changed.
-func (list *SkipList) SetMaxLevel(newLevel int) (ok bool) {
+func (list *List[K]) SetMaxLevel(newLevel int) (ok bool) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
 	if 1 > newLevel || newLevel > 64 || newLevel == list.maxLevel {
 		return false
 	}
@@ -137,17 +333,23 @@ func (list *SkipList) SetMaxLevel(newLevel int) (ok bool) {
 	// Downsizing, just truncate the existing data
 	if list.maxLevel > newLevel {
 		list.next = list.next[:newLevel]
+		list.span = list.span[:newLevel]
 		list.prevNodesCache = list.prevNodesCache[:newLevel]
+		list.rankCache = list.rankCache[:newLevel]
 		list.probTable = probabilityTable(list.probability, newLevel)
 		list.maxLevel = newLevel
 		return true
 	}
 
 	// Upsizing, need to embiggen arrays
-	next := make([]*Element, newLevel)
+	next := make([]atomic.Pointer[Element[K]], newLevel)
 	copy(next, list.next)
 	list.next = next
-	list.prevNodesCache = make([]*elementNode, newLevel)
+	span := make([]uint32, newLevel)
+	copy(span, list.span)
+	list.span = span
+	list.prevNodesCache = make([]*elementNode[K], newLevel)
+	list.rankCache = make([]uint32, newLevel)
 	list.probTable = probabilityTable(list.probability, newLevel)
 	list.maxLevel = newLevel
 
@@ -156,12 +358,12 @@ func (list *SkipList) SetMaxLevel(newLevel int) (ok bool) {
 
 // SetProbability changes the current P value of the list.
 // It doesn't alter any existing data, only changes how future insert heights are calculated.
-func (list *SkipList) SetProbability(newProbability float64) {
+func (list *List[K]) SetProbability(newProbability float64) {
 	list.probability = newProbability
 	list.probTable = probabilityTable(list.probability, list.maxLevel)
 }
 
-func (list *SkipList) randLevel() (level int) {
+func (list *List[K]) randLevel() (level int) {
 	// Our random number source only has Int63(), so we have to produce a float64 from it
 	// Reference: https://golang.org/src/math/rand/rand.go#L150
 	r := float64(list.randSource.Int63()) / (1 << 63)
@@ -184,16 +386,75 @@ func probabilityTable(probability float64, maxLevel int) (table []float64) {
 	return table
 }
 
-// New creates a new skip list with default parameters. Returns a pointer to the new list.
-func New() *SkipList {
-	return &SkipList{
-		elementNode:    elementNode{next: make([]*Element, DefaultMaxLevel)},
-		prevNodesCache: make([]*elementNode, DefaultMaxLevel),
-		maxLevel:       DefaultMaxLevel,
+// newList creates a new list with the given Comparer and maxLevel.
+// maxLevel must be between 1 and 64 inclusive, or newList will panic.
+func newList[K any](cmp Comparer[K], maxLevel int) *List[K] {
+	if maxLevel < 1 || maxLevel > 64 {
+		panic("maxLevel for a List must be a positive integer <= 64")
+	}
+
+	return &List[K]{
+		elementNode:    elementNode[K]{next: make([]atomic.Pointer[Element[K]], maxLevel), span: make([]uint32, maxLevel)},
+		prevNodesCache: make([]*elementNode[K], maxLevel),
+		rankCache:      make([]uint32, maxLevel),
+		maxLevel:       maxLevel,
 
 		// Every new list gets its own PRNG source so they don't block one another
 		randSource:  rand.New(rand.NewSource(time.Now().UnixNano())),
 		probability: DefaultProbability,
-		probTable:   probabilityTable(DefaultProbability, DefaultMaxLevel),
+		probTable:   probabilityTable(DefaultProbability, maxLevel),
+		cmp:         cmp,
+	}
+}
+
+// NewGeneric creates a new skip list keyed by K, ordered by cmp, with
+// default max level and probability parameters.
+func NewGeneric[K any](cmp Comparer[K]) *List[K] {
+	return newList[K](cmp, DefaultMaxLevel)
+}
+
+// New creates a new skip list with default parameters. Returns a pointer to the new list.
+func New() *SkipList {
+	return NewWithMaxLevel(DefaultMaxLevel)
+}
+
+// NewWithMaxLevel creates a new skip list with the specified maxLevel.
+// maxLevel must be between 1 and 64 inclusive, or NewWithMaxLevel will panic.
+func NewWithMaxLevel(maxLevel int) *SkipList {
+	return newList[float64](float64Comparer{}, maxLevel)
+}
+
+// NewFloat64 creates a new float64-keyed skip list. It is equivalent to
+// New, and exists for parity with NewGeneric for callers who want the
+// float64 case to read as "one of the keyed constructors".
+func NewFloat64() *SkipList {
+	return New()
+}
+
+// NewWithArena creates a new skip list whose Elements are allocated out of a
+// pre-grown arena instead of individually on the heap, trading a little
+// extra resident memory for fewer, cheaper allocations on insert-heavy
+// workloads. initialBytes is the arena's starting size; it grows (doubling)
+// on demand. The arena backs the Element struct and its per-level
+// next/span slices, so once the arena's chunks have room, the only heap
+// allocation a Set against an arena-backed list makes is the boxed value
+// (see BenchmarkIncSetArena).
+func NewWithArena(initialBytes int) *SkipList {
+	list := New()
+	list.arena = newArena[float64](initialBytes)
+	return list
+}
+
+// BytesAllocated returns the number of bytes reserved by the list's arena,
+// or 0 if the list was not created with NewWithArena. It takes list.mutex
+// since arena.alloc (called from Set) mutates arena state under the same
+// lock.
+func (list *List[K]) BytesAllocated() int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if list.arena == nil {
+		return 0
 	}
+	return list.arena.bytesAllocated()
 }
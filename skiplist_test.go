@@ -8,7 +8,7 @@ import (
 )
 
 var benchList *SkipList
-var discard *Element
+var discard *Element[float64]
 
 func init() {
 	// Initialize a big SkipList for the Get() benchmark
@@ -20,13 +20,14 @@ func init() {
 
 	// Display the sizes of our basic structs
 	var sl SkipList
-	var el Element
+	var el Element[float64]
 	fmt.Printf("Structure sizes: SkipList is %v, Element is %v bytes\n", unsafe.Sizeof(sl), unsafe.Sizeof(el))
 }
 
 func checkSanity(list *SkipList, t *testing.T) {
 	// each level must be correctly ordered
-	for k, v := range list.next {
+	for k := range list.next {
+		v := list.next[k].Load()
 		//t.Log("Level", k)
 
 		if v == nil {
@@ -40,16 +41,21 @@ func checkSanity(list *SkipList, t *testing.T) {
 		next := v
 		cnt := 1
 
-		for next.next[k] != nil {
-			if !(next.next[k].key >= next.key) {
-				t.Fatalf("next key value must be greater than prev key value. [next:%v] [prev:%v]", next.next[k].key, next.key)
+		for next.next[k].Load() != nil {
+			nn := next.next[k].Load()
+			if !(nn.key >= next.key) {
+				t.Fatalf("next key value must be greater than prev key value. [next:%v] [prev:%v]", nn.key, next.key)
 			}
 
-			if k > len(next.next) {
-				t.Fatalf("node's level must be no less than current level. [cur:%v] [node:%v]", k, next.next)
+			if k > len(nn.next) {
+				t.Fatalf("node's level must be no less than current level. [cur:%v] [node:%v]", k, nn.next)
 			}
 
-			next = next.next[k]
+			if k == 0 && nn.prev.Load() != next {
+				t.Fatalf("next.prev must point back to cur at level 0. [cur:%v] [next.prev:%v]", next.key, nn.prev.Load())
+			}
+
+			next = nn
 			cnt++
 		}
 
@@ -57,6 +63,10 @@ func checkSanity(list *SkipList, t *testing.T) {
 			if cnt != list.Length {
 				t.Fatalf("list len must match the level 0 nodes count. [cur:%v] [level0:%v]", cnt, list.Length)
 			}
+
+			if list.tail.Load() != next {
+				t.Fatalf("list.tail must be the last level 0 node. [tail:%v] [last:%v]", list.tail.Load(), next)
+			}
 		}
 	}
 }
@@ -87,15 +97,15 @@ func TestBasicIntCRUD(t *testing.T) {
 	v5 := list.Get(90)
 	v6 := list.Get(0)
 
-	if v1 == nil || v1.value.(int) != 1 || v1.key != 10 {
+	if v1 == nil || v1.Value().(int) != 1 || v1.key != 10 {
 		t.Fatal(`wrong "10" value (expected "1")`, v1)
 	}
 
-	if v2 == nil || v2.value.(int) != 2 {
+	if v2 == nil || v2.Value().(int) != 2 {
 		t.Fatal(`wrong "60" value (expected "2")`)
 	}
 
-	if v3 == nil || v3.value.(int) != 9 {
+	if v3 == nil || v3.Value().(int) != 9 {
 		t.Fatal(`wrong "30" value (expected "9")`)
 	}
 
@@ -103,7 +113,7 @@ func TestBasicIntCRUD(t *testing.T) {
 		t.Fatal(`found value for key "20", which should have been deleted`)
 	}
 
-	if v5 == nil || v5.value.(int) != 5 {
+	if v5 == nil || v5.Value().(int) != 5 {
 		t.Fatal(`wrong "90" value`)
 	}
 
@@ -112,6 +122,306 @@ func TestBasicIntCRUD(t *testing.T) {
 	}
 }
 
+func TestReverseIteration(t *testing.T) {
+	list := New()
+
+	var i float64
+	for i = 1; i <= 100; i++ {
+		list.Set(i, i)
+	}
+	checkSanity(list, t)
+
+	if list.Back().Key() != 100 {
+		t.Fatal("wrong back element", list.Back().Key())
+	}
+
+	cnt := 0
+	for c := list.Back(); c != nil; c = c.Prev() {
+		if c.Key() != 100-float64(cnt) {
+			t.Fatal("wrong key while iterating backward", c.Key())
+		}
+		cnt++
+	}
+	if cnt != 100 {
+		t.Fatal("wrong number of elements visited backward", cnt)
+	}
+
+	list.Remove(50)
+	checkSanity(list, t)
+
+	list.Remove(100)
+	checkSanity(list, t)
+	if list.Back().Key() != 99 {
+		t.Fatal("wrong back element after removing the tail", list.Back().Key())
+	}
+}
+
+func TestSeek(t *testing.T) {
+	list := New()
+
+	for _, k := range []float64{10, 20, 30, 40, 50} {
+		list.Set(k, k)
+	}
+
+	if v := list.Seek(25); v == nil || v.Key() != 30 {
+		t.Fatal("Seek(25) should land on 30", v)
+	}
+
+	if v := list.Seek(30); v == nil || v.Key() != 30 {
+		t.Fatal("Seek(30) should land on 30", v)
+	}
+
+	if v := list.Seek(60); v != nil {
+		t.Fatal("Seek(60) should find nothing", v)
+	}
+
+	if v := list.SeekLE(25); v == nil || v.Key() != 20 {
+		t.Fatal("SeekLE(25) should land on 20", v)
+	}
+
+	if v := list.SeekLE(30); v == nil || v.Key() != 30 {
+		t.Fatal("SeekLE(30) should land on 30", v)
+	}
+
+	if v := list.SeekLE(5); v != nil {
+		t.Fatal("SeekLE(5) should find nothing", v)
+	}
+}
+
+func TestRange(t *testing.T) {
+	list := New()
+
+	// dense duplicate-key inserts: later Set calls update, not duplicate
+	for _, k := range []float64{10, 10, 20, 20, 30} {
+		list.Set(k, k)
+	}
+	// sparse keys
+	for _, k := range []float64{50, 80, 130} {
+		list.Set(k, k)
+	}
+	checkSanity(list, t)
+
+	var got []float64
+	list.Range(20, 80, func(e *Element[float64]) bool {
+		got = append(got, e.Key())
+		return true
+	})
+	want := []float64{20, 30, 50, 80}
+	if len(got) != len(want) {
+		t.Fatalf("Range(20, 80) got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Range(20, 80) got %v, want %v", got, want)
+		}
+	}
+
+	got = nil
+	list.RangeWithOptions(20, 80, RangeOptions{FromInclusive: false, ToInclusive: false}, func(e *Element[float64]) bool {
+		got = append(got, e.Key())
+		return true
+	})
+	want = []float64{30, 50}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("exclusive Range(20, 80) got %v, want %v", got, want)
+	}
+
+	got = nil
+	list.RangeWithOptions(20, 80, RangeOptions{FromInclusive: true, ToInclusive: true, Limit: 2}, func(e *Element[float64]) bool {
+		got = append(got, e.Key())
+		return true
+	})
+	if len(got) != 2 || got[0] != 20 || got[1] != 30 {
+		t.Fatalf("limited Range(20, 80) got %v", got)
+	}
+
+	got = nil
+	list.RangeWithOptions(20, 80, RangeOptions{FromInclusive: true, ToInclusive: true, Reverse: true}, func(e *Element[float64]) bool {
+		got = append(got, e.Key())
+		return true
+	})
+	want = []float64{80, 50, 30, 20}
+	if len(got) != len(want) {
+		t.Fatalf("reverse Range(20, 80) got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("reverse Range(20, 80) got %v, want %v", got, want)
+		}
+	}
+
+	got = nil
+	list.Range(20, 80, func(e *Element[float64]) bool {
+		got = append(got, e.Key())
+		return e.Key() < 30
+	})
+	if len(got) != 2 || got[1] != 30 {
+		t.Fatalf("Range should stop early when fn returns false, got %v", got)
+	}
+}
+
+func TestLockFreeReadersUnderConcurrentWriters(t *testing.T) {
+	list := New()
+
+	const writers = 4
+	const readers = 8
+	const perWriter = 2000
+
+	var writerWG sync.WaitGroup
+	writerWG.Add(writers)
+	for w := 0; w < writers; w++ {
+		w := w
+		go func() {
+			defer writerWG.Done()
+			base := w * perWriter
+			for i := 0; i < perWriter; i++ {
+				key := float64(base + i)
+				list.Set(key, key)
+				if i%2 == 0 {
+					list.Remove(key)
+				}
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	var readerWG sync.WaitGroup
+	readerWG.Add(readers)
+	for r := 0; r < readers; r++ {
+		go func() {
+			defer readerWG.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				for k := 0; k < writers*perWriter; k += 7 {
+					if e := list.Get(float64(k)); e != nil && e.Key() != e.Value().(float64) {
+						t.Errorf("torn read: key %v has value %v", e.Key(), e.Value())
+					}
+				}
+			}
+		}()
+	}
+
+	writerWG.Wait()
+	close(done)
+	readerWG.Wait()
+
+	if list.Length != writers*perWriter/2 {
+		t.Fatalf("wrong length after concurrent writers: got %v, want %v", list.Length, writers*perWriter/2)
+	}
+	checkSanity(list, t)
+}
+
+func TestConcurrentReverseIteration(t *testing.T) {
+	list := New()
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		for i := 1000; i < 2000; i++ {
+			list.Set(float64(i), i)
+		}
+		wg.Done()
+	}()
+
+	go func() {
+		for c := list.Back(); c != nil; c = c.Prev() {
+		}
+		wg.Done()
+	}()
+
+	wg.Wait()
+}
+
+func TestRank(t *testing.T) {
+	list := New()
+
+	var i float64
+	for i = 1; i <= 100; i++ {
+		list.Set(i*10, i)
+	}
+	checkSanity(list, t)
+
+	for i = 1; i <= 100; i++ {
+		if r := list.Rank(i * 10); r != int(i) {
+			t.Fatalf("wrong rank for key %v: got %v, want %v", i*10, r, i)
+		}
+	}
+
+	if r := list.Rank(5); r != 0 {
+		t.Fatal("rank of an absent key must be 0", r)
+	}
+
+	for i = 1; i <= 100; i++ {
+		if e := list.GetByRank(int(i)); e == nil || e.Key() != i*10 {
+			t.Fatalf("wrong element for rank %v: got %v", i, e)
+		}
+	}
+
+	if list.GetByRank(0) != nil {
+		t.Fatal("GetByRank(0) must be nil")
+	}
+	if list.GetByRank(101) != nil {
+		t.Fatal("GetByRank(101) must be nil: out of range")
+	}
+
+	// updating an existing key must not change any ranks
+	list.Set(500, -1)
+	if r := list.Rank(500); r != 50 {
+		t.Fatal("updating a key must not change its rank", r)
+	}
+	checkSanity(list, t)
+
+	list.Remove(500)
+	checkSanity(list, t)
+	if r := list.Rank(510); r != 50 {
+		t.Fatal("rank must shift down after removing a lower-ranked key", r)
+	}
+	if list.Rank(500) != 0 {
+		t.Fatal("removed key must have rank 0")
+	}
+}
+
+func TestRankMaxLevelNode(t *testing.T) {
+	list := NewWithMaxLevel(4)
+
+	var i float64
+	for i = 1; i <= 50; i++ {
+		list.Set(i, i)
+	}
+	checkSanity(list, t)
+
+	// find and remove whichever node actually reached maxLevel, to exercise
+	// folding its spans into its predecessors on every level, the top one
+	// included (no level lies above the removed node's height).
+	var maxHeightKey float64 = -1
+	for c := list.Front(); c != nil; c = c.Next() {
+		if len(c.next) == list.maxLevel {
+			maxHeightKey = c.Key()
+			break
+		}
+	}
+	if maxHeightKey < 0 {
+		t.Fatal("no node reached maxLevel; test setup is not exercising the intended path")
+	}
+
+	list.Remove(maxHeightKey)
+	checkSanity(list, t)
+
+	if list.Length != 49 {
+		t.Fatal("wrong length after removing the maxLevel node", list.Length)
+	}
+	if r := list.Rank(maxHeightKey); r != 0 {
+		t.Fatal("removed key must have rank 0", r)
+	}
+}
+
 func TestChangeLevel(t *testing.T) {
 	var i float64
 	list := New()
@@ -136,7 +446,7 @@ func TestChangeLevel(t *testing.T) {
 	}
 
 	for c := list.Front(); c != nil; c = c.Next() {
-		if c.key*10 != c.value.(float64) {
+		if c.key*10 != c.Value().(float64) {
 			t.Fatal("wrong list element value")
 		}
 	}
@@ -147,6 +457,40 @@ func TestMaxLevel(t *testing.T) {
 	list.Set(0, struct{}{})
 }
 
+func TestArena(t *testing.T) {
+	list := NewWithArena(64)
+
+	var i float64
+	for i = 1; i <= 500; i++ {
+		list.Set(i, i*10)
+	}
+
+	checkSanity(list, t)
+
+	if list.Length != 500 {
+		t.Fatal("wrong list length", list.Length)
+	}
+
+	if list.BytesAllocated() == 0 {
+		t.Fatal("expected arena to report a non-zero number of bytes allocated")
+	}
+
+	for c := list.Front(); c != nil; c = c.Next() {
+		if c.key*10 != c.Value().(float64) {
+			t.Fatal("wrong list element value")
+		}
+	}
+
+	list.Set(250, -1)
+	if v := list.Get(250); v == nil || v.Value().(int) != -1 {
+		t.Fatal("wrong value after updating an existing key in an arena-backed list")
+	}
+
+	if New().BytesAllocated() != 0 {
+		t.Fatal("lists created without NewWithArena should report 0 bytes allocated")
+	}
+}
+
 func TestChangeProbability(t *testing.T) {
 	list := New()
 
@@ -196,6 +540,17 @@ func BenchmarkIncSet(b *testing.B) {
 	b.SetBytes(int64(b.N))
 }
 
+func BenchmarkIncSetArena(b *testing.B) {
+	b.ReportAllocs()
+	list := NewWithArena(1 << 20)
+
+	for i := 0; i < b.N; i++ {
+		list.Set(float64(i), [1]byte{})
+	}
+
+	b.SetBytes(int64(b.N))
+}
+
 func BenchmarkIncGet(b *testing.B) {
 	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
@@ -3,41 +3,136 @@ package skiplist
 import (
 	"math/rand"
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
-type elementNode struct {
-	next []*Element
+// Comparer defines an ordering over keys of type K. It lets List be used
+// with key types other than float64 -- strings, []byte, composite structs,
+// anything with a total order -- without forcing a lossy encoding into a
+// float64.
+type Comparer[K any] interface {
+	// Compare returns a negative number if a < b, zero if a == b, and a
+	// positive number if a > b.
+	Compare(a, b K) int
 }
 
-type Element struct {
-	elementNode
-	key   float64
-	value interface{}
+// float64Comparer is the Comparer SkipList (List[float64]) is built on.
+type float64Comparer struct{}
+
+func (float64Comparer) Compare(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+type elementNode[K any] struct {
+	// next[i] is an atomic pointer so Get (and Seek) can walk the list
+	// without taking list.mutex; only writers (Set/Remove), which hold
+	// the mutex for their full duration, ever Store into it.
+	next []atomic.Pointer[Element[K]]
+
+	// span[i] is the number of bottom-level nodes between this node and
+	// next[i], inclusive of next[i] itself. It lets Rank and GetByRank
+	// answer order-statistic queries in O(log n) instead of walking
+	// level 0, the same trick Redis's sorted-set skiplist uses. Span
+	// bookkeeping is writer-lock-protected only, not atomic.
+	span []uint32
+}
+
+// Element is a node in a List, keyed by K and ordered by the Comparer[K]
+// supplied to NewGeneric (or, for the float64-keyed SkipList, by
+// float64Comparer).
+type Element[K any] struct {
+	elementNode[K]
+	key K
+
+	// value is boxed behind an atomic pointer so a lock-free Get can read
+	// it concurrently with Set's in-place update of an existing key
+	// without tearing. atomic.Value isn't usable here since callers are
+	// free to Set the same key to a different concrete type later.
+	value atomic.Pointer[any]
+
+	// prev links to the preceding Element at the bottom level only,
+	// enabling reverse iteration from a cursor. It is nil for the first
+	// element in the list. It's an atomic pointer for the same reason
+	// next is: Prev() must be safe to call lock-free while Set/Remove
+	// relink it from a writer holding list.mutex.
+	prev atomic.Pointer[Element[K]]
+
+	// deleted is set before a removed Element is unlinked, so a lock-free
+	// Get/Seek that is already positioned on the node (via a next pointer
+	// read before the unlink finished propagating across levels) treats
+	// it as absent instead of returning a logically-removed node.
+	deleted atomic.Bool
 }
 
 // Key allows retrieval of the key for a given Element
-func (e *Element) Key() float64 {
+func (e *Element[K]) Key() K {
 	return e.key
 }
 
 // Value allows retrieval of the value for a given Element
-func (e *Element) Value() interface{} {
-	return e.value
+func (e *Element[K]) Value() interface{} {
+	if v := e.value.Load(); v != nil {
+		return *v
+	}
+	return nil
+}
+
+// setValue atomically stores value, boxing it behind a fresh pointer so
+// concurrent Get calls never observe a torn interface word.
+func (e *Element[K]) setValue(value interface{}) {
+	e.value.Store(&value)
 }
 
 // Next returns the following Element or nil if we're at the end of the list.
 // Only operates on the bottom level of the skip list (a fully linked list).
-func (element *Element) Next() *Element {
-	return element.next[0]
+func (element *Element[K]) Next() *Element[K] {
+	return element.next[0].Load()
+}
+
+// Prev returns the preceding Element or nil if we're at the front of the list.
+// Only operates on the bottom level of the skip list (a fully linked list).
+func (element *Element[K]) Prev() *Element[K] {
+	return element.prev.Load()
 }
 
-type SkipList struct {
-	elementNode
+// elementFromNode recovers the *Element an *elementNode is embedded in.
+// It relies on elementNode being Element's first field, so the two share
+// an address; this lets getPrevElementNodes's []*elementNode results
+// double as bottom-level predecessors without a second, parallel cache.
+func elementFromNode[K any](n *elementNode[K]) *Element[K] {
+	return (*Element[K])(unsafe.Pointer(n))
+}
+
+// List is a skip list keyed by K, ordered by the Comparer supplied to
+// NewGeneric. SkipList is List[float64], ordered by float64Comparer --
+// the float64-keyed case most callers want is just this generic core
+// instantiated, not a separate implementation.
+type List[K any] struct {
+	elementNode[K]
 	maxLevel       int
 	Length         int
 	randSource     rand.Source
 	probability    float64
 	probTable      []float64
 	mutex          sync.RWMutex
-	prevNodesCache []*elementNode
+	prevNodesCache []*elementNode[K]
+	rankCache      []uint32
+	arena          *arena[K]
+	cmp            Comparer[K]
+
+	// tail is atomic for the same reason elementNode.next is: Back() must
+	// be safe to call lock-free while Set/Remove update it under list.mutex.
+	tail atomic.Pointer[Element[K]]
 }
+
+// SkipList is a skip list keyed by float64, ordered numerically. It is
+// List[float64] under the hood; see List for the shared implementation.
+type SkipList = List[float64]